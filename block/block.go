@@ -0,0 +1,105 @@
+// Package block implements IEEE 488.2 arbitrary block response data, the
+// `#<n><len><bytes>` (definite-length) and `#0<bytes><LF>` (indefinite-length)
+// framing SCPI instruments use to return waveforms, screenshots, and
+// mass-memory files.
+package block
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ReadBlock reads a single IEEE 488.2 arbitrary block from r and returns its
+// payload.
+//
+// Both the definite-length form (#<n><len><bytes>) and the
+// indefinite-length form (#0<bytes><LF>) are supported. The
+// indefinite-length form has no declared length, so its payload is read up
+// to, and not including, the next line feed; it must not be used when the
+// payload itself can contain an embedded line feed.
+func ReadBlock(r io.Reader) ([]byte, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("failed to read the block header: %s", err)
+	}
+	if header[0] != '#' {
+		return nil, fmt.Errorf("invalid block header: %q", header)
+	}
+
+	ndigits := header[1]
+	if ndigits == '0' {
+		payload, err := br.ReadBytes('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the indefinite-length block: %s", err)
+		}
+		return trimTerminator(payload), nil
+	}
+
+	n := int(ndigits - '0')
+	if n < 1 || n > 9 {
+		return nil, fmt.Errorf("invalid block length digit: %q", ndigits)
+	}
+
+	lenDigits := make([]byte, n)
+	if _, err := io.ReadFull(br, lenDigits); err != nil {
+		return nil, fmt.Errorf("failed to read the block length: %s", err)
+	}
+	length, err := strconv.Atoi(string(lenDigits))
+	if err != nil {
+		return nil, fmt.Errorf("invalid block length %q: %s", lenDigits, err)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil, fmt.Errorf("failed to read the block payload: %s", err)
+	}
+
+	// A definite-length block is still followed by the response's own
+	// message terminator (LF or CRLF), since the declared length only
+	// covers the payload. Discard everything through the next LF so the
+	// reader is left positioned at the start of the next response; a lone
+	// CR is not treated as a terminator.
+	if _, err := br.ReadBytes('\n'); err != nil {
+		return nil, fmt.Errorf("failed to read the block terminator: %s", err)
+	}
+	return payload, nil
+}
+
+func trimTerminator(b []byte) []byte {
+	b = bytesTrimSuffix(b, '\n')
+	b = bytesTrimSuffix(b, '\r')
+	return b
+}
+
+func bytesTrimSuffix(b []byte, c byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == c {
+		return b[:len(b)-1]
+	}
+	return b
+}
+
+// EncodeBlock writes payload to w as a definite-length IEEE 488.2 arbitrary
+// block. IEEE 488.2 allows at most 9 digits to declare the payload length,
+// so payload must be shorter than 1e9 bytes.
+func EncodeBlock(w io.Writer, payload []byte) error {
+	length := strconv.Itoa(len(payload))
+	if len(length) > 9 {
+		return fmt.Errorf("payload is %d bytes, too large to encode as a definite-length block (max 999999999)", len(payload))
+	}
+	header := fmt.Sprintf("#%d%s", len(length), length)
+
+	if _, err := io.WriteString(w, header); err != nil {
+		return fmt.Errorf("failed to write the block header: %s", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("failed to write the block payload: %s", err)
+	}
+	return nil
+}
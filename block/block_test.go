@@ -0,0 +1,117 @@
+package block
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadBlock(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want []byte
+	}{
+		"Definite": {
+			in:   "#15hello\n",
+			want: []byte("hello"),
+		},
+		"DefiniteWithBinary": {
+			in:   "#13\x00\x01\xff\n",
+			want: []byte{0x00, 0x01, 0xff},
+		},
+		"DefiniteWithCRLF": {
+			in:   "#15hello\r\n",
+			want: []byte("hello"),
+		},
+		"IndefiniteLF": {
+			in:   "#0hello\n",
+			want: []byte("hello"),
+		},
+		"IndefiniteCRLF": {
+			in:   "#0hello\r\n",
+			want: []byte("hello"),
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := ReadBlock(bytes.NewReader([]byte(tt.in)))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadBlock_InvalidHeader(t *testing.T) {
+	if _, err := ReadBlock(bytes.NewReader([]byte("hello"))); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestEncodeBlock(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeBlock(&buf, []byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := buf.String(), "#15hello"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestEncodeBlock_TooLarge(t *testing.T) {
+	payload := make([]byte, 1_000_000_000)
+
+	var buf bytes.Buffer
+	if err := EncodeBlock(&buf, payload); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestEncodeBlock_RoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte{0xaa, 0xbb}, 1024)
+
+	var buf bytes.Buffer
+	if err := EncodeBlock(&buf, payload); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	buf.WriteByte('\n')
+
+	got, err := ReadBlock(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("got %v bytes, want %v bytes", len(got), len(payload))
+	}
+}
+
+// TestReadBlock_LeavesReaderInSync verifies that, after a definite-length
+// block and its trailing terminator are consumed, the shared bufio.Reader
+// is positioned at the start of the next response rather than still
+// sitting on that terminator. Channel and TCPClient share one bufio.Reader
+// across QueryBlockContext and QueryContext calls, so a block read that
+// doesn't consume its own terminator would desync every read after it.
+func TestReadBlock_LeavesReaderInSync(t *testing.T) {
+	br := bufio.NewReader(strings.NewReader("#15hello\n+0,\"No error\"\n"))
+
+	got, err := ReadBlock(br)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []byte("hello"); !bytes.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	next, err := br.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "+0,\"No error\"\n"; string(next) != want {
+		t.Fatalf("got %q, want %q", next, want)
+	}
+}
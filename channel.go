@@ -0,0 +1,143 @@
+package scpi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/scizorman/go-scpi/block"
+)
+
+// Conn is the transport connection a Channel frames SCPI messages over.
+// *net.TCPConn satisfies Conn, as will the serial and VXI-11 transports.
+type Conn interface {
+	io.Reader
+	io.Writer
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// Terminator is the message terminator a Channel uses to delimit responses.
+type Terminator int
+
+const (
+	// LF terminates responses with a single line feed. This is the SCPI default.
+	LF Terminator = iota
+	// CRLF terminates responses with a carriage return followed by a line feed.
+	CRLF
+)
+
+// delim returns the byte bufio.Reader scans for when framing a response.
+// CRLF still scans for '\n', since it is always the final byte.
+func (t Terminator) delim() byte {
+	return '\n'
+}
+
+// suffix returns the terminator bytes to strip from a framed response.
+func (t Terminator) suffix() []byte {
+	switch t {
+	case CRLF:
+		return []byte("\r\n")
+	default:
+		return []byte("\n")
+	}
+}
+
+// Channel owns the framing of a SCPI connection, decoupling the transport
+// (TCP, serial, VXI-11, ...) from the command/response protocol. It reads
+// responses of arbitrary size, terminated by the configured Terminator,
+// and honors context cancellation via the underlying Conn's deadlines.
+type Channel struct {
+	conn Conn
+	r    *bufio.Reader
+	term Terminator
+}
+
+// NewChannel returns a new Channel that reads and writes over conn,
+// terminating responses on term.
+func NewChannel(conn Conn, term Terminator) *Channel {
+	return &Channel{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		term: term,
+	}
+}
+
+// WriteCommand writes cmd to the device, appending the message terminator.
+func (c *Channel) WriteCommand(ctx context.Context, cmd string) error {
+	defer c.watchCancel(ctx, c.conn.SetWriteDeadline)()
+
+	b := append([]byte(cmd), c.term.suffix()...)
+	if _, err := c.conn.Write(b); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to write the command '%s': %w", cmd, err)
+	}
+	return nil
+}
+
+// ReadResponse reads a single response, delimited by the channel's
+// terminator, growing its buffer as needed to accommodate responses of any
+// size.
+func (c *Channel) ReadResponse(ctx context.Context) ([]byte, error) {
+	defer c.watchCancel(ctx, c.conn.SetReadDeadline)()
+
+	b, err := c.r.ReadBytes(c.term.delim())
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to read the response: %w", err)
+	}
+	return bytes.TrimSuffix(b, c.term.suffix()), nil
+}
+
+// ReadBlock reads a single IEEE 488.2 arbitrary block response (see package
+// block), honoring context cancellation the same way ReadResponse does.
+func (c *Channel) ReadBlock(ctx context.Context) ([]byte, error) {
+	defer c.watchCancel(ctx, c.conn.SetReadDeadline)()
+
+	b, err := block.ReadBlock(c.r)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// watchCancel arranges for ctx cancellation to unblock an in-flight
+// Read/Write by forcing the connection's deadline into the past. The
+// returned func must be called once the operation finishes to stop the
+// watch; otherwise a later, unrelated operation could have its deadline
+// clobbered by this ctx's cancellation.
+func (c *Channel) watchCancel(ctx context.Context, set func(time.Time) error) func() {
+	if deadline, ok := ctx.Deadline(); ok {
+		set(deadline)
+	}
+
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+			set(time.Now())
+		case <-stop:
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+		set(time.Time{})
+	}
+}
@@ -0,0 +1,162 @@
+package scpi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestChannel_ReadResponse(t *testing.T) {
+	tests := map[string]struct {
+		term Terminator
+		sent string
+		want string
+	}{
+		"LF": {
+			term: LF,
+			sent: "+0,\"No error\"\n",
+			want: "+0,\"No error\"",
+		},
+		"CRLF": {
+			term: CRLF,
+			sent: "+0,\"No error\"\r\n",
+			want: "+0,\"No error\"",
+		},
+		"Large": {
+			term: LF,
+			sent: string(make([]byte, 8192)) + "\n",
+			want: string(make([]byte, 8192)),
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			defer server.Close()
+
+			ch := NewChannel(client, tt.term)
+
+			go func() {
+				server.Write([]byte(tt.sent))
+			}()
+
+			got, err := ch.ReadResponse(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// errConn is a Conn whose Read and Write always fail with a net.Error, for
+// verifying that Channel preserves the underlying error through its chain.
+type errConn struct {
+	err error
+}
+
+func (c *errConn) Read([]byte) (int, error)           { return 0, c.err }
+func (c *errConn) Write([]byte) (int, error)          { return 0, c.err }
+func (c *errConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *errConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func TestChannel_WriteCommand_PreservesErrorChain(t *testing.T) {
+	netErr := &fakeNetError{timeout: true}
+	ch := NewChannel(&errConn{err: netErr}, LF)
+
+	err := ch.WriteCommand(context.Background(), "*CLS")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var got *fakeNetError
+	if !errors.As(err, &got) {
+		t.Fatalf("got %v, want the underlying net.Error in the chain", err)
+	}
+}
+
+func TestChannel_ReadResponse_PreservesErrorChain(t *testing.T) {
+	netErr := &fakeNetError{timeout: true}
+	ch := NewChannel(&errConn{err: netErr}, LF)
+
+	_, err := ch.ReadResponse(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var got *fakeNetError
+	if !errors.As(err, &got) {
+		t.Fatalf("got %v, want the underlying net.Error in the chain", err)
+	}
+}
+
+func TestChannel_ReadResponse_ContextCanceled(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ch := NewChannel(client, LF)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := ch.ReadResponse(ctx); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+func TestChannel_ReadBlock_LeavesReaderInSync(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ch := NewChannel(client, LF)
+
+	go func() {
+		server.Write([]byte("#15hello\n+0,\"No error\"\n"))
+	}()
+
+	got, err := ch.ReadBlock(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "hello"; string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	next, err := ch.ReadResponse(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `+0,"No error"`; string(next) != want {
+		t.Fatalf("got %q, want %q", next, want)
+	}
+}
+
+func TestChannel_WriteCommand(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ch := NewChannel(client, LF)
+
+	done := make(chan []byte, 1)
+	go func() {
+		buf := make([]byte, 64)
+		l, _ := server.Read(buf)
+		done <- buf[:l]
+	}()
+
+	if err := ch.WriteCommand(context.Background(), "*CLS"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := string(<-done), "*CLS\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package scpi
 
 import (
 	"context"
+	"net/url"
 	"time"
 )
 
@@ -37,12 +38,71 @@ type Client interface {
 	QueryContext(ctx context.Context, cmd string) (res string, err error)
 }
 
-// NewClient returns a new client of a device controlled using SCPI commands.
-func NewClient(proto, addr string, timeout time.Duration) (Client, error) {
-	switch proto {
-	case "tcp":
+// SRQNotifier is implemented by clients with a native Service Request
+// interrupt channel, such as VXI-11's srq_handler callback or USBTMC's
+// interrupt-IN endpoint. When a Client implements it,
+// Handler.WaitForCompleteAsync waits on it instead of polling *STB?.
+type SRQNotifier interface {
+	// WaitForSRQ blocks until the device asserts SRQ, or ctx is done.
+	WaitForSRQ(ctx context.Context) error
+}
+
+// Confirmer is implemented by clients whose ExecContext/BulkExecContext
+// confirm a command by querying the device after writing it (see
+// TCPClient.queryError) and can return a *ConfirmationError if that
+// confirmation fails. When a Client implements it, Handler retries a
+// *ConfirmationError by calling ConfirmContext instead of re-executing the
+// command, so a retry can't double-execute a stateful command whose write
+// already reached the device.
+type Confirmer interface {
+	// ConfirmContext re-confirms that cmd, already written in an earlier
+	// ExecContext/BulkExecContext call, took effect, without resending it.
+	ConfirmContext(ctx context.Context, cmd string) error
+}
+
+// BlockQuerier is implemented by clients that can return the raw payload of
+// an IEEE 488.2 arbitrary block response, without CRLF stripping. This is
+// required to capture binary results such as waveforms, screenshots, and
+// mass-memory files, which the string-oriented QueryContext would corrupt.
+type BlockQuerier interface {
+	// QueryBlockContext issues cmd and returns the raw block payload.
+	QueryBlockContext(ctx context.Context, cmd string) ([]byte, error)
+}
+
+// TransportFactory constructs a Client for addr, honoring timeout. addr may
+// be a bare address (e.g. "192.0.2.5:5025") or a URI whose scheme names the
+// transport (e.g. "vxi-11://192.0.2.10/inst0").
+type TransportFactory func(addr string, timeout time.Duration) (Client, error)
+
+var transports = map[string]TransportFactory{
+	"tcp": func(addr string, timeout time.Duration) (Client, error) {
 		return NewTCPClient(addr, timeout)
-	default:
+	},
+}
+
+// RegisterTransport registers a TransportFactory under proto, so that
+// NewClient(proto, addr, timeout) can construct it. It panics if proto is
+// already registered.
+func RegisterTransport(proto string, factory TransportFactory) {
+	if _, dup := transports[proto]; dup {
+		panic("scpi: RegisterTransport called twice for transport " + proto)
+	}
+	transports[proto] = factory
+}
+
+// NewClient returns a new client of a device controlled using SCPI
+// commands. proto selects the registered transport ("tcp", "serial",
+// "vxi-11", "usbtmc", ...); it may be omitted in favor of a URI-style addr
+// whose scheme names the transport instead, e.g.
+// NewClient("", "vxi-11://192.0.2.10/inst0", timeout).
+func NewClient(proto, addr string, timeout time.Duration) (Client, error) {
+	if u, err := url.Parse(addr); proto == "" && err == nil && u.Scheme != "" {
+		proto = u.Scheme
+	}
+
+	factory, ok := transports[proto]
+	if !ok {
 		return nil, InvalidProtocolError(proto)
 	}
+	return factory(addr, timeout)
 }
@@ -11,14 +11,63 @@ import (
 type InvalidProtocolError string
 
 func (e InvalidProtocolError) Error() string {
-	return fmt.Sprintf("invalid protocol %s", e)
+	return fmt.Sprintf("invalid protocol %s", string(e))
+}
+
+// InvalidFormatError occurs if a SYST:ERR? response doesn't match the
+// "<code>,<message>" format, with <message> optionally quoted.
+type InvalidFormatError string
+
+func (e InvalidFormatError) Error() string {
+	return fmt.Sprintf("invalid format: %s", string(e))
+}
+
+// ErrorClass identifies the hundred-range a SCPI error code falls into, as
+// defined by IEEE 488.2 / SCPI-99 volume 2. It implements error so it can
+// be used as an errors.Is target, e.g. errors.Is(err, scpi.ErrClassQuery).
+type ErrorClass struct {
+	name string
+}
+
+func (c *ErrorClass) Error() string {
+	return c.name
+}
+
+// The standard SCPI error classes, derived from a CommandError's Code().
+var (
+	ErrClassCommand        = &ErrorClass{"command error"}
+	ErrClassExecution      = &ErrorClass{"execution error"}
+	ErrClassDeviceSpecific = &ErrorClass{"device-specific error"}
+	ErrClassQuery          = &ErrorClass{"query error"}
+	ErrClassPowerOn        = &ErrorClass{"power-on error"}
+)
+
+// classify returns the ErrorClass whose hundred-range code falls in, or nil
+// if code is outside the standard ranges (e.g. a positive, device-defined
+// warning code).
+func classify(code int) *ErrorClass {
+	switch {
+	case code <= -100 && code >= -199:
+		return ErrClassCommand
+	case code <= -200 && code >= -299:
+		return ErrClassExecution
+	case code <= -300 && code >= -399:
+		return ErrClassDeviceSpecific
+	case code <= -400 && code >= -499:
+		return ErrClassQuery
+	case code <= -500 && code >= -599:
+		return ErrClassPowerOn
+	default:
+		return nil
+	}
 }
 
 // CommandError is the error of SCPI commands.
 type CommandError struct {
-	cmd  string
-	code int
-	msg  string
+	cmd   string
+	code  int
+	msg   string
+	class *ErrorClass
 }
 
 // Code returns the error code of a SCPI device.
@@ -26,33 +75,75 @@ func (e *CommandError) Code() int {
 	return e.code
 }
 
+// Class returns the standard SCPI error class derived from Code's
+// hundred-range, or nil if the code falls outside the standard ranges.
+func (e *CommandError) Class() *ErrorClass {
+	return e.class
+}
+
 func (e *CommandError) Error() string {
 	return fmt.Sprintf("'%s' returned %d: %s", e.cmd, e.code, e.msg)
 }
 
-var cmdErrRegexp = regexp.MustCompile(`([+-]\d+),\"(.*?)\"`)
+// Is reports whether target is e's ErrorClass, so callers can write
+// errors.Is(err, scpi.ErrClassQuery) instead of inspecting Code themselves.
+func (e *CommandError) Is(target error) bool {
+	return e.class != nil && e.class == target
+}
+
+var cmdErrRegexp = regexp.MustCompile(`^([+-]\d+),\s*"?(.*?)"?\s*$`)
 
-func confirmError(cmd, errRes string) error {
+// parseError parses a single SYST:ERR?/SYST:ERR:NEXT? response. It returns
+// a nil *CommandError and a nil error for the "no error" response (code 0).
+func parseError(cmd, errRes string) (*CommandError, error) {
 	re := cmdErrRegexp.Copy()
 	g := re.FindStringSubmatch(errRes)
 	if g == nil {
-		return fmt.Errorf("invalid error format: %s", errRes)
+		return nil, InvalidFormatError(errRes)
 	}
 
 	code, err := strconv.Atoi(g[1])
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if code == 0 {
-		return nil
+		return nil, nil
 	}
 
-	msg := strings.ToLower(g[2])
+	return &CommandError{
+		cmd:   cmd,
+		code:  code,
+		msg:   strings.ToLower(g[2]),
+		class: classify(code),
+	}, nil
+}
+
+// ConfirmationError wraps a failure to confirm that a command already
+// written to the device took effect (see TCPClient.queryError), as
+// distinct from a failure to write the command itself. Because the write
+// already reached the instrument, retrying by re-executing cmd risks
+// double-executing a stateful command such as *TRG or :INIT; only the
+// confirmation step itself is safe to redo.
+type ConfirmationError struct {
+	cmd string
+	err error
+}
+
+func (e *ConfirmationError) Error() string {
+	return fmt.Sprintf("failed to confirm '%s' took effect: %s", e.cmd, e.err)
+}
+
+func (e *ConfirmationError) Unwrap() error {
+	return e.err
+}
 
-	cmdErr := &CommandError{
-		cmd:  cmd,
-		code: code,
-		msg:  msg,
+func confirmError(cmd, errRes string) error {
+	cmdErr, err := parseError(cmd, errRes)
+	if err != nil {
+		return err
+	}
+	if cmdErr == nil {
+		return nil
 	}
 	return cmdErr
 }
@@ -2,6 +2,7 @@ package scpi
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -89,3 +90,32 @@ func TestConfirmError(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandError_Class(t *testing.T) {
+	tests := map[string]struct {
+		code int
+		want *ErrorClass
+	}{
+		"Command":        {code: -102, want: ErrClassCommand},
+		"Execution":      {code: -220, want: ErrClassExecution},
+		"DeviceSpecific": {code: -310, want: ErrClassDeviceSpecific},
+		"Query":          {code: -420, want: ErrClassQuery},
+		"PowerOn":        {code: -500, want: ErrClassPowerOn},
+		"Unclassified":   {code: -999, want: nil},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			cmdErr, err := parseError("foo", fmt.Sprintf("%d,\"err\"", tt.code))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := cmdErr.Class(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if tt.want != nil && !errors.Is(cmdErr, tt.want) {
+				t.Fatalf("errors.Is(cmdErr, %v) = false, want true", tt.want)
+			}
+		})
+	}
+}
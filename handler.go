@@ -2,22 +2,180 @@ package scpi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// maxDrainedErrors is how many pending errors DrainErrors expects at most;
+// if the response after that many is still an error, it gives up, guarding
+// against a device whose queue never reports "no error".
+const maxDrainedErrors = 32
+
 // Handler is a handler for a device controlled using SCPI commands.
 type Handler struct {
 	Client
+
+	retry       *RetryPolicy
+	drainErrors bool
+}
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithRetryPolicy configures the Handler to retry ExecContext, QueryContext,
+// and BulkExecContext calls according to policy on transient errors.
+func WithRetryPolicy(policy RetryPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.retry = &policy
+	}
+}
+
+// WithErrorDraining configures ExecContext and BulkExecContext to drain any
+// errors left in the device's queue after the command, via DrainErrors, and
+// attach them to the returned error with errors.Join.
+func WithErrorDraining() HandlerOption {
+	return func(h *Handler) {
+		h.drainErrors = true
+	}
 }
 
 // NewHandler returns a new handler for a device controlled using SCPI commands.
-func NewHandler(client Client) *Handler {
-	return &Handler{
+func NewHandler(client Client, opts ...HandlerOption) *Handler {
+	h := &Handler{
 		Client: client,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ExecContext executes a SCPI command, retrying according to the Handler's
+// RetryPolicy if one is configured, and draining any errors left in the
+// device's queue if WithErrorDraining is configured.
+//
+// A retry that follows a *ConfirmationError only re-confirms the command,
+// via the Client's Confirmer implementation if it has one, instead of
+// re-executing cmd: the write already reached the device, so resending it
+// could double-execute a stateful command such as *TRG or :INIT.
+func (h *Handler) ExecContext(ctx context.Context, cmd string) error {
+	run := h.retryableExec(ctx, cmd, func() error {
+		return h.Client.ExecContext(ctx, cmd)
+	})
+
+	var err error
+	if h.retry != nil {
+		err = h.retry.do(ctx, run)
+	} else {
+		err = run()
+	}
+	if !h.drainErrors {
+		return err
+	}
+	return h.joinPendingErrors(ctx, err)
+}
+
+// BulkExecContext executes multiple SCPI commands, retrying according to
+// the Handler's RetryPolicy if one is configured, and draining any errors
+// left in the device's queue if WithErrorDraining is configured.
+//
+// A retry that follows a *ConfirmationError only re-confirms the commands,
+// via the Client's Confirmer implementation if it has one, instead of
+// re-executing them: see ExecContext.
+func (h *Handler) BulkExecContext(ctx context.Context, cmds ...string) error {
+	run := h.retryableExec(ctx, strings.Join(cmds, ";"), func() error {
+		return h.Client.BulkExecContext(ctx, cmds...)
+	})
+
+	var err error
+	if h.retry != nil {
+		err = h.retry.do(ctx, run)
+	} else {
+		err = run()
+	}
+	if !h.drainErrors {
+		return err
+	}
+	return h.joinPendingErrors(ctx, err)
+}
+
+// retryableExec returns a closure that, on its first call or after any
+// non-confirmation failure, runs exec in full. After a *ConfirmationError,
+// it instead calls ConfirmContext(ctx, cmd) on the Client's Confirmer, if
+// it has one, so a retry re-confirms cmd rather than re-executing it.
+func (h *Handler) retryableExec(ctx context.Context, cmd string, exec func() error) func() error {
+	confirmer, ok := h.Client.(Confirmer)
+
+	var lastErr error
+	return func() error {
+		var confirmErr *ConfirmationError
+		if ok && errors.As(lastErr, &confirmErr) {
+			lastErr = confirmer.ConfirmContext(ctx, cmd)
+			return lastErr
+		}
+		lastErr = exec()
+		return lastErr
+	}
+}
+
+// QueryContext queries the device for the results of the specified command,
+// retrying according to the Handler's RetryPolicy, if one is configured.
+func (h *Handler) QueryContext(ctx context.Context, cmd string) (res string, err error) {
+	if h.retry == nil {
+		return h.Client.QueryContext(ctx, cmd)
+	}
+	err = h.retry.do(ctx, func() error {
+		res, err = h.Client.QueryContext(ctx, cmd)
+		return err
+	})
+	return res, err
+}
+
+// DrainErrors repeatedly queries SYST:ERR:NEXT? until the device reports
+// "no error" (code 0), returning every pending CommandError it read. It
+// stops early, returning what it has read so far alongside an error, if
+// more than maxDrainedErrors are pending.
+func (h *Handler) DrainErrors(ctx context.Context) ([]*CommandError, error) {
+	var errs []*CommandError
+	for i := 0; i < maxDrainedErrors+1; i++ {
+		res, err := h.Client.QueryContext(ctx, "SYST:ERR:NEXT?")
+		if err != nil {
+			return errs, err
+		}
+
+		cmdErr, err := parseError("SYST:ERR:NEXT?", res)
+		if err != nil {
+			return errs, err
+		}
+		if cmdErr == nil {
+			return errs, nil
+		}
+		errs = append(errs, cmdErr)
+	}
+	return errs, fmt.Errorf("SYST:ERR:NEXT? still pending after draining %d errors", len(errs))
+}
+
+// joinPendingErrors drains any errors left in the device's queue beyond the
+// one the command's own ExecContext already surfaced in err, and joins
+// them onto err via errors.Join.
+func (h *Handler) joinPendingErrors(ctx context.Context, err error) error {
+	pending, drainErr := h.DrainErrors(ctx)
+
+	errs := make([]error, 0, len(pending)+2)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, cmdErr := range pending {
+		errs = append(errs, cmdErr)
+	}
+	if drainErr != nil {
+		errs = append(errs, drainErr)
+	}
+	return errors.Join(errs...)
 }
 
 // Reset resets the instrument to a factory pre-defined condition and clears the error log.
@@ -45,6 +203,96 @@ func (h *Handler) WaitForComplete(ctx context.Context, timeout time.Duration) er
 	}
 }
 
+// statusByteRQS is bit 6 (RQS/MSS) of the Status Byte Register: the
+// instrument sets it to signal a Service Request.
+const statusByteRQS = 1 << 6
+
+// WaitForCompleteAsyncOptions configures WaitForCompleteAsync.
+type WaitForCompleteAsyncOptions struct {
+	// PollInterval is the initial interval between *STB? polls, used when
+	// the Client has no native SRQNotifier. Defaults to 10ms.
+	PollInterval time.Duration
+
+	// MaxInterval caps the exponentially-growing poll interval. Defaults
+	// to 1s.
+	MaxInterval time.Duration
+}
+
+// WaitForCompleteAsync waits for all queued operations to complete without
+// stalling the link for the duration of the operation, unlike
+// WaitForComplete.
+//
+// It clears the Standard Event Status Register so a previous operation's
+// latched completion bit can't be mistaken for this one's, arms the device
+// to report completion on the Status Byte (*ESE 1; *SRE 32), issues *OPC
+// without querying it, and then waits for the Service Request: via the
+// Client's native SRQ channel if it implements SRQNotifier, or otherwise by
+// polling *STB? at an exponentially-growing interval until bit 6 (RQS/MSS)
+// is set.
+func (h *Handler) WaitForCompleteAsync(ctx context.Context, opts WaitForCompleteAsyncOptions) error {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 10 * time.Millisecond
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = time.Second
+	}
+
+	if _, err := h.QueryEventStatusRegister(ctx); err != nil {
+		return err
+	}
+	if err := h.SetEventStatusEnable(ctx, 1); err != nil {
+		return err
+	}
+	if err := h.SetServiceRequestEnable(ctx, 32); err != nil {
+		return err
+	}
+	if err := h.ExecContext(ctx, "*OPC"); err != nil {
+		return err
+	}
+
+	if notifier, ok := h.Client.(SRQNotifier); ok {
+		return notifier.WaitForSRQ(ctx)
+	}
+	return h.pollForSRQ(ctx, opts.PollInterval, opts.MaxInterval)
+}
+
+// pollForSRQ polls *STB? at an exponentially-growing interval, capped at
+// maxInterval, until bit 6 (RQS/MSS) is set.
+func (h *Handler) pollForSRQ(ctx context.Context, interval, maxInterval time.Duration) error {
+	for {
+		stb, err := h.QueryStatusByteRegister(ctx)
+		if err != nil {
+			return err
+		}
+		if stb&statusByteRQS != 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// QueryBlockContext queries the device for the results of the specified
+// command and returns the raw IEEE 488.2 block payload, without CRLF
+// stripping. The underlying Client must implement BlockQuerier.
+func (h *Handler) QueryBlockContext(ctx context.Context, cmd string) ([]byte, error) {
+	bq, ok := h.Client.(BlockQuerier)
+	if !ok {
+		return nil, fmt.Errorf("client does not support block queries")
+	}
+	return bq.QueryBlockContext(ctx, cmd)
+}
+
 // Trigger triggers the device if, and only if,
 // Bus Triggering is the type of trigger event selected.
 // Otherwise, this command is ignored.
@@ -0,0 +1,315 @@
+package scpi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClient is a minimal, in-memory Client for exercising Handler logic
+// without a real transport.
+type fakeClient struct {
+	execErr   error
+	errQueue  []string
+	queryResp map[string]string
+	stbQueue  []string
+	esrQueue  []string
+}
+
+func (c *fakeClient) Close() error { return nil }
+
+func (c *fakeClient) Exec(cmd string) error { return c.ExecContext(context.Background(), cmd) }
+
+func (c *fakeClient) ExecContext(ctx context.Context, cmd string) error { return c.execErr }
+
+func (c *fakeClient) BulkExec(cmds ...string) error {
+	return c.BulkExecContext(context.Background(), cmds...)
+}
+
+func (c *fakeClient) BulkExecContext(ctx context.Context, cmds ...string) error {
+	return c.execErr
+}
+
+func (c *fakeClient) Ping() error { return nil }
+
+func (c *fakeClient) PingContext(ctx context.Context) error { return nil }
+
+func (c *fakeClient) Query(cmd string) (string, error) {
+	return c.QueryContext(context.Background(), cmd)
+}
+
+func (c *fakeClient) QueryContext(ctx context.Context, cmd string) (string, error) {
+	switch cmd {
+	case "SYST:ERR:NEXT?":
+		if len(c.errQueue) == 0 {
+			return "+0,\"No error\"", nil
+		}
+		next := c.errQueue[0]
+		c.errQueue = c.errQueue[1:]
+		return next, nil
+	case "*STB?":
+		if len(c.stbQueue) == 0 {
+			return "+0", nil
+		}
+		next := c.stbQueue[0]
+		if len(c.stbQueue) > 1 {
+			c.stbQueue = c.stbQueue[1:]
+		}
+		return next, nil
+	case "*ESR?":
+		if len(c.esrQueue) == 0 {
+			return "+0", nil
+		}
+		next := c.esrQueue[0]
+		c.esrQueue = c.esrQueue[1:]
+		return next, nil
+	}
+	return c.queryResp[cmd], nil
+}
+
+func TestHandler_DrainErrors(t *testing.T) {
+	client := &fakeClient{
+		errQueue: []string{"-101,\"Invalid character\"", "-220,\"Parameter error\""},
+	}
+	h := NewHandler(client)
+
+	errs, err := h.DrainErrors(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2", len(errs))
+	}
+	if got, want := errs[0].Code(), -101; got != want {
+		t.Fatalf("got code %d, want %d", got, want)
+	}
+	if got, want := errs[1].Code(), -220; got != want {
+		t.Fatalf("got code %d, want %d", got, want)
+	}
+}
+
+func TestHandler_DrainErrors_ExactlyMaxDrainedErrors(t *testing.T) {
+	errQueue := make([]string, maxDrainedErrors)
+	for i := range errQueue {
+		errQueue[i] = "-101,\"Invalid character\""
+	}
+	client := &fakeClient{errQueue: errQueue}
+	h := NewHandler(client)
+
+	errs, err := h.DrainErrors(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(errs) != maxDrainedErrors {
+		t.Fatalf("got %d errors, want %d", len(errs), maxDrainedErrors)
+	}
+}
+
+func TestHandler_ExecContext_WithErrorDraining(t *testing.T) {
+	client := &fakeClient{
+		errQueue: []string{"-101,\"Invalid character\""},
+	}
+	h := NewHandler(client, WithErrorDraining())
+
+	err := h.ExecContext(context.Background(), "*CLS")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("got %v, want a *CommandError in the chain", err)
+	}
+	if got, want := cmdErr.Code(), -101; got != want {
+		t.Fatalf("got code %d, want %d", got, want)
+	}
+}
+
+func TestHandler_ExecContext_WithoutErrorDraining(t *testing.T) {
+	client := &fakeClient{
+		errQueue: []string{"-101,\"Invalid character\""},
+	}
+	h := NewHandler(client)
+
+	if err := h.ExecContext(context.Background(), "*CLS"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// confirmFailClient models a Client whose ExecContext/BulkExecContext
+// write the command successfully but whose post-write confirmation can
+// fail transiently, as TCPClient.ExecContext does when the SYST:ERR?
+// confirmation read times out. It implements Confirmer so Handler can
+// retry just the confirmation.
+type confirmFailClient struct {
+	execCalls    int
+	confirmCalls int
+	confirmErrs  []error
+}
+
+func (c *confirmFailClient) Close() error { return nil }
+
+func (c *confirmFailClient) Exec(cmd string) error {
+	return c.ExecContext(context.Background(), cmd)
+}
+
+func (c *confirmFailClient) ExecContext(ctx context.Context, cmd string) error {
+	c.execCalls++
+	return c.ConfirmContext(ctx, cmd)
+}
+
+func (c *confirmFailClient) BulkExec(cmds ...string) error {
+	return c.BulkExecContext(context.Background(), cmds...)
+}
+
+func (c *confirmFailClient) BulkExecContext(ctx context.Context, cmds ...string) error {
+	c.execCalls++
+	return c.ConfirmContext(ctx, strings.Join(cmds, ";"))
+}
+
+func (c *confirmFailClient) Ping() error { return nil }
+
+func (c *confirmFailClient) PingContext(ctx context.Context) error { return nil }
+
+func (c *confirmFailClient) Query(cmd string) (string, error) {
+	return c.QueryContext(context.Background(), cmd)
+}
+
+func (c *confirmFailClient) QueryContext(ctx context.Context, cmd string) (string, error) {
+	return "", nil
+}
+
+func (c *confirmFailClient) ConfirmContext(ctx context.Context, cmd string) error {
+	i := c.confirmCalls
+	c.confirmCalls++
+	if i < len(c.confirmErrs) {
+		return c.confirmErrs[i]
+	}
+	return nil
+}
+
+func TestHandler_ExecContext_RetriesConfirmationNotCommand(t *testing.T) {
+	client := &confirmFailClient{
+		confirmErrs: []error{&ConfirmationError{cmd: "*TRG", err: &fakeNetError{timeout: true}}},
+	}
+	h := NewHandler(client, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, Factor: 1}))
+
+	if err := h.ExecContext(context.Background(), "*TRG"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.execCalls != 1 {
+		t.Fatalf("got %d ExecContext calls, want 1: a confirmation-only retry must not re-send the command", client.execCalls)
+	}
+	if client.confirmCalls != 2 {
+		t.Fatalf("got %d confirmation calls, want 2", client.confirmCalls)
+	}
+}
+
+func TestHandler_BulkExecContext_RetriesConfirmationNotCommand(t *testing.T) {
+	client := &confirmFailClient{
+		confirmErrs: []error{&ConfirmationError{cmd: "*RST;*CLS", err: &fakeNetError{timeout: true}}},
+	}
+	h := NewHandler(client, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, Factor: 1}))
+
+	if err := h.BulkExecContext(context.Background(), "*RST", "*CLS"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.execCalls != 1 {
+		t.Fatalf("got %d BulkExecContext calls, want 1: a confirmation-only retry must not re-send the commands", client.execCalls)
+	}
+	if client.confirmCalls != 2 {
+		t.Fatalf("got %d confirmation calls, want 2", client.confirmCalls)
+	}
+}
+
+func TestHandler_WaitForCompleteAsync_Polls(t *testing.T) {
+	client := &fakeClient{
+		stbQueue: []string{"+0", "+0", "+64"},
+	}
+	h := NewHandler(client)
+
+	opts := WaitForCompleteAsyncOptions{PollInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+	if err := h.WaitForCompleteAsync(context.Background(), opts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// staleESRClient simulates a device whose Standard Event Status Register
+// still has the OPC bit latched from a prior completed operation. Until
+// *ESR? is queried (which clears it, as real devices do), *STB? reports
+// completion (bit 6 set) on every poll, regardless of whether the new
+// operation the Handler is waiting on has actually finished.
+type staleESRClient struct {
+	*fakeClient
+	stale bool
+	polls int
+}
+
+func (c *staleESRClient) QueryContext(ctx context.Context, cmd string) (string, error) {
+	switch cmd {
+	case "*ESR?":
+		c.stale = false
+		return "+0", nil
+	case "*STB?":
+		c.polls++
+		if c.stale {
+			return "+64", nil
+		}
+	}
+	return c.fakeClient.QueryContext(ctx, cmd)
+}
+
+func TestHandler_WaitForCompleteAsync_ClearsStaleESRLatch(t *testing.T) {
+	client := &staleESRClient{
+		fakeClient: &fakeClient{stbQueue: []string{"+0", "+64"}},
+		stale:      true,
+	}
+	h := NewHandler(client)
+
+	opts := WaitForCompleteAsyncOptions{PollInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	if err := h.WaitForCompleteAsync(context.Background(), opts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.polls != 2 {
+		t.Fatalf("got %d *STB? polls, want 2: a stale ESR latch must not short-circuit the wait", client.polls)
+	}
+}
+
+func TestHandler_WaitForCompleteAsync_ContextDone(t *testing.T) {
+	client := &fakeClient{stbQueue: []string{"+0"}}
+	h := NewHandler(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	opts := WaitForCompleteAsyncOptions{PollInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	if err := h.WaitForCompleteAsync(ctx, opts); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
+
+// notifierClient is a fakeClient that reports SRQ completion immediately,
+// as a transport with a native SRQ interrupt channel would.
+type notifierClient struct {
+	*fakeClient
+	waited bool
+}
+
+func (c *notifierClient) WaitForSRQ(ctx context.Context) error {
+	c.waited = true
+	return nil
+}
+
+func TestHandler_WaitForCompleteAsync_PrefersSRQNotifier(t *testing.T) {
+	client := &notifierClient{fakeClient: &fakeClient{stbQueue: []string{"+0"}}}
+	h := NewHandler(client)
+
+	if err := h.WaitForCompleteAsync(context.Background(), WaitForCompleteAsyncOptions{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !client.waited {
+		t.Fatal("expected WaitForSRQ to be called instead of polling *STB?")
+	}
+}
@@ -0,0 +1,117 @@
+package scpi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// RetryPolicy configures exponential-backoff-with-jitter retries of
+// transient SCPI/transport errors.
+//
+// When attempt n fails (counting the first try as attempt 0), the delay
+// before the next attempt is
+// min(MaxDelay, BaseDelay * Factor^n) * (1 +/- Jitter*rand), after which
+// ctx.Done() is re-checked and the command is re-issued, up to MaxAttempts
+// times in total.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to attempt the command,
+	// including the first try. Zero disables retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Factor is the multiplicative backoff factor applied per attempt.
+	Factor float64
+
+	// Jitter is the fractional +/- randomization applied to each delay,
+	// e.g. 0.2 randomizes the delay by +/-20%.
+	Jitter float64
+
+	// Retryable reports whether err is safe to retry. If nil,
+	// DefaultRetryable is used.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy retries up to 3 attempts with a 100ms base delay,
+// doubling up to a 2s cap, +/-20% jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Factor:      2,
+	Jitter:      0.2,
+}
+
+// DefaultRetryable reports whether err is safe to retry: a transient
+// transport error, a SYST:ERR? code of -363 (input buffer overrun) or -365
+// (time out error), or a context.DeadlineExceeded. Each of these indicates
+// the command never took effect on the instrument, so retrying cannot
+// double-execute a stateful command such as *TRG or :INIT.
+func DefaultRetryable(err error) bool {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		code := cmdErr.Code()
+		return code == -363 || code == -365
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout() || netErr.Temporary()
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return DefaultRetryable(err)
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.BaseDelay) * math.Pow(p.Factor, float64(attempt))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	jitter := 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(d * jitter)
+}
+
+// do runs fn, retrying according to p until it succeeds, ctx is done, its
+// error is classified as unsafe to retry, or MaxAttempts is reached.
+func (p RetryPolicy) do(ctx context.Context, fn func() error) error {
+	if p.MaxAttempts < 1 {
+		return fn()
+	}
+
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == p.MaxAttempts-1 || !p.retryable(err) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+package scpi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Do(t *testing.T) {
+	t.Run("SucceedsWithoutRetry", func(t *testing.T) {
+		p := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+		calls := 0
+		err := p.do(context.Background(), func() error {
+			calls++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("RetriesRetryableError", func(t *testing.T) {
+		p := RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			Factor:      1,
+			Retryable:   func(err error) bool { return true },
+		}
+		calls := 0
+		err := p.do(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Fatalf("got %d calls, want 3", calls)
+		}
+	})
+
+	t.Run("StopsOnUnretryableError", func(t *testing.T) {
+		wantErr := errors.New("fatal")
+		p := RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			Retryable:   func(err error) bool { return false },
+		}
+		calls := 0
+		err := p.do(context.Background(), func() error {
+			calls++
+			return wantErr
+		})
+		if err != wantErr {
+			t.Fatalf("got %v, want %v", err, wantErr)
+		}
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("StopsAfterMaxAttempts", func(t *testing.T) {
+		p := RetryPolicy{
+			MaxAttempts: 2,
+			BaseDelay:   time.Millisecond,
+			Retryable:   func(err error) bool { return true },
+		}
+		calls := 0
+		err := p.do(context.Background(), func() error {
+			calls++
+			return errors.New("transient")
+		})
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if calls != 2 {
+			t.Fatalf("got %d calls, want 2", calls)
+		}
+	})
+
+	t.Run("StopsOnContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		p := RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			Retryable:   func(err error) bool { return true },
+		}
+		calls := 0
+		err := p.do(ctx, func() error {
+			calls++
+			return errors.New("transient")
+		})
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+	})
+}
+
+// fakeNetError is a minimal net.Error for exercising DefaultRetryable's
+// errors.As(err, &netErr) branch without a real socket.
+type fakeNetError struct {
+	timeout bool
+}
+
+func (e *fakeNetError) Error() string   { return "fake net error" }
+func (e *fakeNetError) Timeout() bool   { return e.timeout }
+func (e *fakeNetError) Temporary() bool { return e.timeout }
+
+func TestDefaultRetryable(t *testing.T) {
+	tests := map[string]struct {
+		in   error
+		want bool
+	}{
+		"InputBufferOverrun": {
+			in:   &CommandError{cmd: "foo", code: -363, msg: "input buffer overrun"},
+			want: true,
+		},
+		"WrappedNetError": {
+			in:   fmt.Errorf("failed to read the response: %w", &fakeNetError{timeout: true}),
+			want: true,
+		},
+		"TimeOutError": {
+			in:   &CommandError{cmd: "foo", code: -365, msg: "time out error"},
+			want: true,
+		},
+		"UnrelatedCommandError": {
+			in:   &CommandError{cmd: "foo", code: -101, msg: "invalid character"},
+			want: false,
+		},
+		"DeadlineExceeded": {
+			in:   context.DeadlineExceeded,
+			want: true,
+		},
+		"Other": {
+			in:   errors.New("boom"),
+			want: false,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := DefaultRetryable(tt.in); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
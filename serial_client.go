@@ -0,0 +1,167 @@
+package scpi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterTransport("serial", newSerialClient)
+}
+
+// serialAddr is a parsed serial:// address, e.g.
+// "serial:///dev/ttyUSB0?baud=115200".
+type serialAddr struct {
+	device string
+	baud   int
+}
+
+func parseSerialAddr(addr string) (serialAddr, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return serialAddr{}, fmt.Errorf("invalid serial address %q: %s", addr, err)
+	}
+
+	baud := 9600
+	if s := u.Query().Get("baud"); s != "" {
+		b, err := strconv.Atoi(s)
+		if err != nil {
+			return serialAddr{}, fmt.Errorf("invalid baud rate %q: %s", s, err)
+		}
+		baud = b
+	}
+
+	return serialAddr{device: u.Path, baud: baud}, nil
+}
+
+// serialPort wraps an open serial device file as a Conn. Opening the file
+// and configuring the line (baud rate, parity, stop bits) is platform-
+// specific; see openSerialPort.
+type serialPort struct {
+	f *os.File
+}
+
+func (p *serialPort) Read(b []byte) (int, error)  { return p.f.Read(b) }
+func (p *serialPort) Write(b []byte) (int, error) { return p.f.Write(b) }
+
+func (p *serialPort) SetReadDeadline(t time.Time) error  { return p.f.SetReadDeadline(t) }
+func (p *serialPort) SetWriteDeadline(t time.Time) error { return p.f.SetWriteDeadline(t) }
+
+func (p *serialPort) Close() error { return p.f.Close() }
+
+// SerialClient is an implementation of the Client interface for RS-232
+// serial connections, framed the same way TCPClient frames TCP.
+type SerialClient struct {
+	port *serialPort
+	ch   *Channel
+}
+
+// newSerialClient is the TransportFactory registered under "serial".
+func newSerialClient(addr string, timeout time.Duration) (Client, error) {
+	parsed, err := parseSerialAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := openSerialPort(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SerialClient{
+		port: port,
+		ch:   NewChannel(port, LF),
+	}, nil
+}
+
+// Close implements the Client Close method.
+func (c *SerialClient) Close() error {
+	return c.port.Close()
+}
+
+// Exec implements the Client Exec method.
+func (c *SerialClient) Exec(cmd string) error {
+	return c.ExecContext(context.Background(), cmd)
+}
+
+// ExecContext implements the Client ExecContext method.
+func (c *SerialClient) ExecContext(ctx context.Context, cmd string) error {
+	if err := c.exec(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to execute the command '%s': %w", cmd, err)
+	}
+	return c.queryError(ctx, cmd)
+}
+
+func (c *SerialClient) exec(ctx context.Context, cmd string) error {
+	return c.ch.WriteCommand(ctx, cmd)
+}
+
+func (c *SerialClient) queryError(ctx context.Context, prevCmd string) error {
+	res, err := c.QueryContext(ctx, "SYST:ERR?")
+	if err != nil {
+		return &ConfirmationError{cmd: prevCmd, err: err}
+	}
+	return confirmError(prevCmd, res)
+}
+
+// ConfirmContext implements the Confirmer method. It re-runs the SYST:ERR?
+// confirmation query for cmd, already written by an earlier
+// ExecContext/BulkExecContext call, without resending cmd itself.
+func (c *SerialClient) ConfirmContext(ctx context.Context, cmd string) error {
+	return c.queryError(ctx, cmd)
+}
+
+// BulkExec implements the Client BulkExec method.
+func (c *SerialClient) BulkExec(cmds ...string) error {
+	return c.BulkExecContext(context.Background(), cmds...)
+}
+
+// BulkExecContext implements the Client BulkExecContext method.
+func (c *SerialClient) BulkExecContext(ctx context.Context, cmds ...string) error {
+	cmd := strings.Join(cmds, ";")
+	return c.ExecContext(ctx, cmd)
+}
+
+// Ping implements the Client Ping method.
+func (c *SerialClient) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+// PingContext implements the Client PingContext method.
+func (c *SerialClient) PingContext(ctx context.Context) error {
+	// BUG(scizorman): PingContext is not implemented yet.
+	return nil
+}
+
+// Query implements the Client Query method.
+func (c *SerialClient) Query(cmd string) (res string, err error) {
+	return c.QueryContext(context.Background(), cmd)
+}
+
+// QueryContext implements the Client QueryContext method.
+func (c *SerialClient) QueryContext(ctx context.Context, cmd string) (res string, err error) {
+	if err := c.exec(ctx, cmd); err != nil {
+		return "", err
+	}
+
+	b, err := c.ch.ReadResponse(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// QueryBlockContext implements the Client BlockQuerier method. It issues cmd
+// and returns the raw IEEE 488.2 block payload, without CRLF stripping.
+func (c *SerialClient) QueryBlockContext(ctx context.Context, cmd string) ([]byte, error) {
+	if err := c.exec(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return c.ch.ReadBlock(ctx)
+}
@@ -0,0 +1,81 @@
+//go:build linux
+
+package scpi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// openTestPTY opens a fresh pseudo-terminal pair and returns the master
+// file plus the slave's device path, letting the serial transport be
+// exercised end-to-end against a real non-TCP character device without
+// needing actual hardware.
+func openTestPTY(t *testing.T) (master *os.File, slavePath string) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("cannot open /dev/ptmx: %s", err)
+	}
+	t.Cleanup(func() { master.Close() })
+
+	var unlock int32
+	if err := ioctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+		t.Skipf("cannot unlock pty: %s", err)
+	}
+
+	var n uint32
+	if err := ioctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); err != nil {
+		t.Skipf("cannot get pty number: %s", err)
+	}
+
+	return master, fmt.Sprintf("/dev/pts/%d", n)
+}
+
+func TestSerialClient_QueryContext_OverRealPTY(t *testing.T) {
+	master, slavePath := openTestPTY(t)
+
+	addr := fmt.Sprintf("serial://%s?baud=9600", slavePath)
+	client, err := newSerialClient(addr, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer client.Close()
+
+	go func() {
+		buf := make([]byte, 64)
+		n, err := master.Read(buf)
+		if err != nil {
+			return
+		}
+		if got, want := string(buf[:n]), "*IDN?\n"; got != want {
+			t.Errorf("got %q written to the port, want %q", got, want)
+		}
+		master.Write([]byte("ACME,Test,0,1.0\n"))
+	}()
+
+	res, err := client.QueryContext(context.Background(), "*IDN?")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "ACME,Test,0,1.0"; res != want {
+		t.Fatalf("got %q, want %q", res, want)
+	}
+}
+
+func TestNewSerialClient_OpenFailure(t *testing.T) {
+	_, err := newSerialClient("serial:///nonexistent-device-for-test?baud=9600", time.Second)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if errors.Is(err, ErrTransportNotImplemented) {
+		t.Fatal("got ErrTransportNotImplemented, want a real open error")
+	}
+}
@@ -0,0 +1,83 @@
+//go:build linux
+
+package scpi
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// termiosBaudRates maps a numeric baud rate to the Bnnn constant termios
+// expects in Termios.Cflag/Ispeed/Ospeed.
+var termiosBaudRates = map[int]uint32{
+	50:     syscall.B50,
+	75:     syscall.B75,
+	110:    syscall.B110,
+	134:    syscall.B134,
+	150:    syscall.B150,
+	200:    syscall.B200,
+	300:    syscall.B300,
+	600:    syscall.B600,
+	1200:   syscall.B1200,
+	1800:   syscall.B1800,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+	230400: syscall.B230400,
+}
+
+// openSerialPort opens addr.device and configures it for raw, 8N1 I/O at
+// addr.baud via the Linux termios ioctls (TCGETS/TCSETS).
+func openSerialPort(addr serialAddr) (*serialPort, error) {
+	speed, ok := termiosBaudRates[addr.baud]
+	if !ok {
+		return nil, fmt.Errorf("unsupported baud rate %d", addr.baud)
+	}
+
+	f, err := os.OpenFile(addr.device, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open serial device %q: %w", addr.device, err)
+	}
+
+	if err := configureTermios(f.Fd(), speed); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to configure serial device %q: %w", addr.device, err)
+	}
+
+	return &serialPort{f: f}, nil
+}
+
+// configureTermios puts the line into raw mode (no echo, no line editing,
+// no special character processing) at speed, 8 data bits, no parity, one
+// stop bit, ignoring modem control lines (CLOCAL) so the open doesn't
+// block waiting for carrier detect.
+func configureTermios(fd uintptr, speed uint32) error {
+	var t syscall.Termios
+	if err := ioctl(fd, syscall.TCGETS, uintptr(unsafe.Pointer(&t))); err != nil {
+		return err
+	}
+
+	t.Iflag = 0
+	t.Oflag = 0
+	t.Lflag = 0
+	t.Cflag = syscall.CS8 | syscall.CLOCAL | syscall.CREAD | speed
+	t.Ispeed = speed
+	t.Ospeed = speed
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+
+	return ioctl(fd, syscall.TCSETS, uintptr(unsafe.Pointer(&t)))
+}
+
+func ioctl(fd, req, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
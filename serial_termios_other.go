@@ -0,0 +1,10 @@
+//go:build !linux
+
+package scpi
+
+// openSerialPort is only implemented on Linux, where the termios ioctls
+// this tree uses (TCGETS/TCSETS) are defined. On other platforms it
+// reports ErrTransportNotImplemented.
+func openSerialPort(addr serialAddr) (*serialPort, error) {
+	return nil, ErrTransportNotImplemented
+}
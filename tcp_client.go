@@ -11,6 +11,7 @@ import (
 // TCPClient is an implementation of the Client interface for TCP network connections.
 type TCPClient struct {
 	conn *net.TCPConn
+	ch   *Channel
 }
 
 // NewTCPClient returns a new TCP client of a device controlled using SCPI commands.
@@ -27,8 +28,10 @@ func NewTCPClient(addr string, timeout time.Duration) (*TCPClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	tcpConn := conn.(*net.TCPConn)
 	client := &TCPClient{
-		conn: conn.(*net.TCPConn),
+		conn: tcpConn,
+		ch:   NewChannel(tcpConn, LF),
 	}
 	return client, nil
 }
@@ -46,27 +49,30 @@ func (c *TCPClient) Exec(cmd string) error {
 // ExecContext implements the Client ExecContext method.
 func (c *TCPClient) ExecContext(ctx context.Context, cmd string) error {
 	if err := c.exec(ctx, cmd); err != nil {
-		return fmt.Errorf("failed to execute the command '%s': %s", cmd, err)
+		return fmt.Errorf("failed to execute the command '%s': %w", cmd, err)
 	}
 	return c.queryError(ctx, cmd)
 }
 
 func (c *TCPClient) exec(ctx context.Context, cmd string) error {
-	b := []byte(cmd + "\n")
-	if _, err := c.conn.Write(b); err != nil {
-		return err
-	}
-	return nil
+	return c.ch.WriteCommand(ctx, cmd)
 }
 
 func (c *TCPClient) queryError(ctx context.Context, prevCmd string) error {
 	res, err := c.QueryContext(ctx, "SYST:ERR?")
 	if err != nil {
-		return err
+		return &ConfirmationError{cmd: prevCmd, err: err}
 	}
 	return confirmError(prevCmd, res)
 }
 
+// ConfirmContext implements the Confirmer method. It re-runs the SYST:ERR?
+// confirmation query for cmd, already written by an earlier
+// ExecContext/BulkExecContext call, without resending cmd itself.
+func (c *TCPClient) ConfirmContext(ctx context.Context, cmd string) error {
+	return c.queryError(ctx, cmd)
+}
+
 // BulkExec implements the Client BulkExec method.
 func (c *TCPClient) BulkExec(cmds ...string) error {
 	return c.BulkExecContext(context.Background(), cmds...)
@@ -100,13 +106,19 @@ func (c *TCPClient) QueryContext(ctx context.Context, cmd string) (res string, e
 		return "", err
 	}
 
-	buf := make([]byte, 1024)
-	l, err := c.conn.Read(buf)
+	b, err := c.ch.ReadResponse(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	res = string(buf[:l])
-	return res, nil
+	return string(b), nil
 }
 
+// QueryBlockContext implements the Client BlockQuerier method. It issues cmd
+// and returns the raw IEEE 488.2 block payload, without CRLF stripping.
+func (c *TCPClient) QueryBlockContext(ctx context.Context, cmd string) ([]byte, error) {
+	if err := c.exec(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return c.ch.ReadBlock(ctx)
+}
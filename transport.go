@@ -0,0 +1,18 @@
+package scpi
+
+import "errors"
+
+// Status: "serial" talks to a real device, via the Linux termios driver in
+// serial_termios_linux.go (on other platforms it still reports
+// ErrTransportNotImplemented; see serial_termios_other.go). "vxi-11" and
+// "usbtmc" remain registry entries with working address parsing (see
+// parseVXI11Addr, parseUSBTMCAddr) and URI dispatch through NewClient, but
+// no I/O: each factory returns ErrTransportNotImplemented once it has
+// validated addr. Landing the ONC RPC/libusb I/O behind them is tracked as
+// follow-up work; see the BUG(scizorman) comment on each transport's
+// factory for what's missing from that one specifically.
+
+// ErrTransportNotImplemented is returned by a registered TransportFactory
+// whose transport is not yet wired up to real hardware/network I/O in this
+// tree.
+var ErrTransportNotImplemented = errors.New("scpi: transport not implemented")
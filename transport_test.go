@@ -0,0 +1,114 @@
+package scpi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewClient_InvalidProtocol(t *testing.T) {
+	_, err := NewClient("bogus", "addr", time.Second)
+	var want InvalidProtocolError
+	if !errors.As(err, &want) {
+		t.Fatalf("got %v, want an InvalidProtocolError", err)
+	}
+}
+
+func TestNewClient_UnimplementedTransports(t *testing.T) {
+	tests := map[string]struct {
+		proto string
+		addr  string
+	}{
+		"VXI11":   {proto: "", addr: "vxi-11://192.0.2.10/inst0"},
+		"USBTMC":  {proto: "", addr: "usbtmc://0x0957/0x1796"},
+		"ByProto": {proto: "vxi-11", addr: "192.0.2.10/inst0"},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			_, err := NewClient(tt.proto, tt.addr, time.Second)
+			if !errors.Is(err, ErrTransportNotImplemented) {
+				t.Fatalf("got %v, want ErrTransportNotImplemented", err)
+			}
+		})
+	}
+}
+
+func TestRegisterTransport_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic registering a duplicate transport")
+		}
+	}()
+	RegisterTransport("tcp", func(addr string, timeout time.Duration) (Client, error) {
+		return nil, nil
+	})
+}
+
+func TestParseSerialAddr(t *testing.T) {
+	got, err := parseSerialAddr("serial:///dev/ttyUSB0?baud=115200")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (serialAddr{device: "/dev/ttyUSB0", baud: 115200}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseSerialAddr_DefaultBaud(t *testing.T) {
+	got, err := parseSerialAddr("serial:///dev/ttyUSB0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (serialAddr{device: "/dev/ttyUSB0", baud: 9600}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseVXI11Addr(t *testing.T) {
+	got, err := parseVXI11Addr("vxi-11://192.0.2.10/inst0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (vxi11Addr{host: "192.0.2.10", device: "inst0"}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseVXI11Addr_DefaultDevice(t *testing.T) {
+	got, err := parseVXI11Addr("vxi-11://192.0.2.10")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (vxi11Addr{host: "192.0.2.10", device: "inst0"}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseUSBTMCAddr(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want usbtmcAddr
+	}{
+		"ByID": {
+			in:   "usbtmc://0x0957/0x1796",
+			want: usbtmcAddr{vendorID: "0x0957", productID: "0x1796"},
+		},
+		"ByDevicePath": {
+			in:   "usbtmc:///dev/usbtmc0",
+			want: usbtmcAddr{device: "/dev/usbtmc0"},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := parseUSBTMCAddr(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package scpi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterTransport("usbtmc", newUSBTMCClient)
+}
+
+// usbtmcAddr is a parsed usbtmc:// address, e.g.
+// "usbtmc://0x0957/0x1796" (vendor ID / product ID) or
+// "usbtmc:///dev/usbtmc0" for a kernel-driver device node.
+type usbtmcAddr struct {
+	vendorID  string
+	productID string
+	device    string
+}
+
+func parseUSBTMCAddr(addr string) (usbtmcAddr, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return usbtmcAddr{}, fmt.Errorf("invalid usbtmc address %q: %s", addr, err)
+	}
+
+	if u.Host == "" {
+		return usbtmcAddr{device: u.Path}, nil
+	}
+
+	productID := strings.TrimPrefix(u.Path, "/")
+	if productID == "" {
+		return usbtmcAddr{}, fmt.Errorf("invalid usbtmc address %q: want vendorID/productID or a device path", addr)
+	}
+	return usbtmcAddr{vendorID: u.Host, productID: productID}, nil
+}
+
+// newUSBTMCClient is the TransportFactory registered under "usbtmc".
+//
+// BUG(scizorman): USBTMC frames each command/response with a bulk-transfer
+// header (MsgID, bTag, TransferSize) over a bulk-IN/bulk-OUT endpoint pair,
+// which needs a libusb binding this tree doesn't vendor yet. This only
+// validates addr and reports ErrTransportNotImplemented. Once a libusb
+// binding is available, wrap its bulk transfer in a Conn implementation
+// the same way NewTCPClient wraps *net.TCPConn, so Handler code stays
+// transport-agnostic.
+func newUSBTMCClient(addr string, timeout time.Duration) (Client, error) {
+	if _, err := parseUSBTMCAddr(addr); err != nil {
+		return nil, err
+	}
+	return nil, ErrTransportNotImplemented
+}
@@ -0,0 +1,49 @@
+package scpi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterTransport("vxi-11", newVXI11Client)
+}
+
+// vxi11Addr is a parsed vxi-11:// address, e.g.
+// "vxi-11://192.0.2.10/inst0".
+type vxi11Addr struct {
+	host   string
+	device string
+}
+
+func parseVXI11Addr(addr string) (vxi11Addr, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return vxi11Addr{}, fmt.Errorf("invalid vxi-11 address %q: %s", addr, err)
+	}
+
+	device := strings.TrimPrefix(u.Path, "/")
+	if device == "" {
+		device = "inst0"
+	}
+
+	return vxi11Addr{host: u.Host, device: device}, nil
+}
+
+// newVXI11Client is the TransportFactory registered under "vxi-11".
+//
+// BUG(scizorman): VXI-11 runs SCPI over ONC RPC's core and abstract data
+// device channels (create_link, device_write, device_read, destroy_link on
+// the portmapper-registered DEVICE_CORE program), which this tree has no
+// ONC RPC client for yet. This only validates addr and reports
+// ErrTransportNotImplemented. Once an ONC RPC client is available, wrap its
+// link the same way NewTCPClient wraps *net.TCPConn, so Handler code stays
+// transport-agnostic.
+func newVXI11Client(addr string, timeout time.Duration) (Client, error) {
+	if _, err := parseVXI11Addr(addr); err != nil {
+		return nil, err
+	}
+	return nil, ErrTransportNotImplemented
+}